@@ -9,11 +9,15 @@ import (
 	"strings"
 
 	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/cloudformation"
 	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/cloudwatch"
 	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/dynamodb"
 	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/iam"
 	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/lambda"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/securityhub"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/sfn"
 	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/sns"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/sqs"
 	"github.com/pulumi/pulumi-command/sdk/go/command/local"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
@@ -93,6 +97,34 @@ func main() {
 		if lambdaTimeout == 0 {
 			lambdaTimeout = 60
 		}
+		scanSchedule := cfg.Get("scanSchedule")
+		if scanSchedule == "" {
+			scanSchedule = "rate(6 hours)"
+		}
+		scanQueueMaxReceives := cfg.GetInt("scanQueueMaxReceives")
+		if scanQueueMaxReceives == 0 {
+			scanQueueMaxReceives = 5
+		}
+		enableRemediation := cfg.GetBool("enableRemediation")
+		approvalEmail := cfg.Get("remediationApprovalEmail")
+		eventRetries := cfg.GetInt("eventRetries")
+		if eventRetries == 0 {
+			eventRetries = 3
+		}
+		eventMaxAgeSeconds := cfg.GetInt("eventMaxAgeSeconds")
+		if eventMaxAgeSeconds == 0 {
+			eventMaxAgeSeconds = 3600
+		}
+		archiveRetentionDays := cfg.GetInt("archiveRetentionDays")
+		if archiveRetentionDays == 0 {
+			archiveRetentionDays = 30
+		}
+		ignoreTagKeys := cfg.Get("ignoreTagKeys")
+		ignoreTagPrefixes := cfg.Get("ignoreTagPrefixes")
+		organizationId := cfg.Get("organizationId")
+		deploySpokeStackSet := cfg.GetBool("deploySpokeStackSet")
+		spokeOuIds := cfg.Get("spokeOuIds")
+		enableSecurityHub := cfg.GetBool("enableSecurityHub")
 
 		// Build Lambda package automatically
 		// Determine platform based on architecture
@@ -126,7 +158,7 @@ pip install \
 
 # Create deployment zip
 cd package && zip -rq ../function.zip . && cd ..
-zip -gq function.zip handler.py agent.py
+zip -gq function.zip handler.py agent.py remediator.py
 zip -grq function.zip tools/
 
 echo "Lambda package built: $(ls -lh function.zip | awk '{print $5}')"
@@ -138,6 +170,7 @@ echo "Lambda package built: $(ls -lh function.zip | awk '{print $5}')"
 				// Rebuild when source files change
 				pulumi.String("../../lambda/handler.py"),
 				pulumi.String("../../lambda/agent.py"),
+				pulumi.String("../../lambda/remediator.py"),
 				pulumi.String("../../lambda/requirements.txt"),
 				pulumi.String("../../lambda/tools/"),
 			},
@@ -159,8 +192,49 @@ echo "Lambda package built: $(ls -lh function.zip | awk '{print $5}')"
 			return err
 		}
 
-		// Create resource-based policy to allow spoke accounts to send events
-		if spokeAccountIds != "" {
+		// Archive all events on the bus so missed events can be replayed via StartReplay after an outage
+		eventArchive, err := cloudwatch.NewEventArchive(ctx, "tag-compliance-event-archive", &cloudwatch.EventArchiveArgs{
+			Name:           pulumi.String("tag-compliance-event-archive"),
+			EventSourceArn: eventBus.Arn,
+			RetentionDays:  pulumi.Int(archiveRetentionDays),
+			Description:    pulumi.String("Replayable archive of tag compliance events"),
+		})
+		if err != nil {
+			return err
+		}
+
+		// Create resource-based policy to allow spoke accounts to send events. When an
+		// organizationId is configured, trust the whole OU via aws:PrincipalOrgID so any account
+		// that joins later is automatically trusted without enumerating root ARNs.
+		if organizationId != "" {
+			busPolicy, _ := json.Marshal(map[string]any{
+				"Version": "2012-10-17",
+				"Statement": []map[string]any{
+					{
+						"Sid":    "AllowOrgAccountsPutEvents",
+						"Effect": "Allow",
+						"Principal": map[string]any{
+							"AWS": "*",
+						},
+						"Action":   "events:PutEvents",
+						"Resource": fmt.Sprintf("arn:aws:events:%s:%s:event-bus/tag-compliance-events", region, currentAccountId),
+						"Condition": map[string]any{
+							"StringEquals": map[string]any{
+								"aws:PrincipalOrgID": organizationId,
+							},
+						},
+					},
+				},
+			})
+
+			_, err = cloudwatch.NewEventBusPolicy(ctx, "tag-compliance-bus-policy", &cloudwatch.EventBusPolicyArgs{
+				EventBusName: eventBus.Name,
+				Policy:       pulumi.String(string(busPolicy)),
+			})
+			if err != nil {
+				return err
+			}
+		} else if spokeAccountIds != "" {
 			spokeAccounts := strings.Split(spokeAccountIds, ",")
 			var principals []string
 			for _, accountId := range spokeAccounts {
@@ -196,6 +270,131 @@ echo "Lambda package built: $(ls -lh function.zip | awk '{print $5}')"
 			}
 		}
 
+		// When deploySpokeStackSet is enabled, provision the spoke-side EventRule, EventTarget,
+		// and cross-account IAM role org-wide via a service-managed StackSet instead of requiring
+		// every spoke account to run its own `pulumi up` against infra/spoke.
+		var spokeStackSet *cloudformation.StackSet
+		if deploySpokeStackSet {
+			spokeStackSet, err = cloudformation.NewStackSet(ctx, "tag-compliance-spoke-stackset", &cloudformation.StackSetArgs{
+				Name:             pulumi.String("tag-compliance-spoke"),
+				Description:      pulumi.String("Spoke-side EventRule, EventTarget, and cross-account IAM role for tag compliance forwarding"),
+				PermissionModel:  pulumi.String("SERVICE_MANAGED"),
+				AutoDeployment: &cloudformation.StackSetAutoDeploymentArgs{
+					Enabled:                     pulumi.Bool(true),
+					RetainStacksOnAccountRemoval: pulumi.Bool(false),
+				},
+				Parameters: pulumi.StringMap{
+					"HubAccountId":   pulumi.String(currentAccountId),
+					"HubRegion":      pulumi.String(region),
+					"HubEventBusArn": eventBus.Arn,
+				},
+				TemplateBody: eventBus.Arn.ApplyT(func(busArn string) (string, error) {
+					template, _ := json.Marshal(map[string]any{
+						"AWSTemplateFormatVersion": "2010-09-09",
+						"Parameters": map[string]any{
+							"HubAccountId":   map[string]any{"Type": "String"},
+							"HubRegion":      map[string]any{"Type": "String"},
+							"HubEventBusArn": map[string]any{"Type": "String"},
+						},
+						"Resources": map[string]any{
+							"CrossAccountRole": map[string]any{
+								"Type": "AWS::IAM::Role",
+								"Properties": map[string]any{
+									"RoleName": "tag-compliance-eventbridge-cross-account",
+									"AssumeRolePolicyDocument": map[string]any{
+										"Version": "2012-10-17",
+										"Statement": []map[string]any{
+											{
+												"Effect":    "Allow",
+												"Principal": map[string]any{"Service": "events.amazonaws.com"},
+												"Action":    "sts:AssumeRole",
+											},
+										},
+									},
+									"Policies": []map[string]any{
+										{
+											"PolicyName": "allow-put-events-to-hub-bus",
+											"PolicyDocument": map[string]any{
+												"Version": "2012-10-17",
+												"Statement": []map[string]any{
+													{
+														"Effect":   "Allow",
+														"Action":   "events:PutEvents",
+														"Resource": map[string]any{"Ref": "HubEventBusArn"},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+							"ForwardRule": map[string]any{
+								"Type": "AWS::Events::Rule",
+								"Properties": map[string]any{
+									"Name":        "tag-compliance-forward-to-hub",
+									"Description": "Forward resource creation events to hub account for tag compliance checking",
+									"EventPattern": map[string]any{
+										"source":      []string{"aws.ec2", "aws.s3", "aws.rds", "aws.lambda", "aws.elasticloadbalancing", "aws.autoscaling"},
+										"detail-type": []string{"AWS API Call via CloudTrail"},
+										"detail": map[string]any{
+											"eventSource": []string{
+												"ec2.amazonaws.com",
+												"s3.amazonaws.com",
+												"rds.amazonaws.com",
+												"lambda.amazonaws.com",
+												"elasticloadbalancing.amazonaws.com",
+												"autoscaling.amazonaws.com",
+											},
+											"eventName": []any{
+												map[string]string{"prefix": "Create"},
+												map[string]string{"prefix": "Run"},
+												map[string]string{"prefix": "Put"},
+												"AllocateAddress",
+											},
+										},
+									},
+									"Targets": []map[string]any{
+										{
+											"Id":      "hub-event-bus",
+											"Arn":     map[string]any{"Ref": "HubEventBusArn"},
+											"RoleArn": map[string]any{"Fn::GetAtt": []string{"CrossAccountRole", "Arn"}},
+										},
+									},
+								},
+							},
+						},
+					})
+					return string(template), nil
+				}).(pulumi.StringOutput),
+				Tags: pulumi.StringMap{
+					"Project":   pulumi.String("TagCompliance"),
+					"ManagedBy": pulumi.String("Pulumi"),
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			var spokeOus pulumi.StringArray
+			for _, ouId := range strings.Split(spokeOuIds, ",") {
+				ouId = strings.TrimSpace(ouId)
+				if ouId != "" {
+					spokeOus = append(spokeOus, pulumi.String(ouId))
+				}
+			}
+
+			_, err = cloudformation.NewStackSetInstance(ctx, "tag-compliance-spoke-stackset-instance", &cloudformation.StackSetInstanceArgs{
+				StackSetName: spokeStackSet.Name,
+				DeploymentTargets: &cloudformation.StackSetInstanceDeploymentTargetsArgs{
+					OrganizationalUnitIds: spokeOus,
+				},
+				Region: pulumi.String(region),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
 		// Create SNS topic for notifications
 		snsTopic, err := sns.NewTopic(ctx, "tag-compliance-notifications", &sns.TopicArgs{
 			Name:        pulumi.String("tag-compliance-notifications"),
@@ -229,6 +428,52 @@ echo "Lambda package built: $(ls -lh function.zip | awk '{print $5}')"
 			return err
 		}
 
+		// Create DynamoDB table mapping alternate tag spellings onto a canonical key, so e.g.
+		// cost-center == CostCenter == costcenter don't require separate rules
+		aliasesTable, err := dynamodb.NewTable(ctx, "tag-compliance-aliases", &dynamodb.TableArgs{
+			Name:        pulumi.String("TagComplianceAliases"),
+			BillingMode: pulumi.String("PAY_PER_REQUEST"),
+			HashKey:     pulumi.String("canonicalKey"),
+			Attributes: dynamodb.TableAttributeArray{
+				&dynamodb.TableAttributeArgs{
+					Name: pulumi.String("canonicalKey"),
+					Type: pulumi.String("S"),
+				},
+			},
+			Tags: pulumi.StringMap{
+				"Project":   pulumi.String("TagCompliance"),
+				"ManagedBy": pulumi.String("Pulumi"),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		// When remediation is enabled, the checker Lambda also needs to read this table (to flag
+		// high-risk resource types for manual approval), so it's provisioned up front alongside
+		// the other DynamoDB tables rather than inside the remediation pipeline block below.
+		var highRiskTypesTable *dynamodb.Table
+		if enableRemediation {
+			highRiskTypesTable, err = dynamodb.NewTable(ctx, "tag-compliance-high-risk-types", &dynamodb.TableArgs{
+				Name:        pulumi.String("TagComplianceHighRiskTypes"),
+				BillingMode: pulumi.String("PAY_PER_REQUEST"),
+				HashKey:     pulumi.String("resourceType"),
+				Attributes: dynamodb.TableAttributeArray{
+					&dynamodb.TableAttributeArgs{
+						Name: pulumi.String("resourceType"),
+						Type: pulumi.String("S"),
+					},
+				},
+				Tags: pulumi.StringMap{
+					"Project":   pulumi.String("TagCompliance"),
+					"ManagedBy": pulumi.String("Pulumi"),
+				},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
 		// Create CloudWatch Log Group for Lambda
 		logGroup, err := cloudwatch.NewLogGroup(ctx, "tag-compliance-logs", &cloudwatch.LogGroupArgs{
 			Name:            pulumi.String("/aws/lambda/tag-compliance-checker"),
@@ -278,61 +523,89 @@ echo "Lambda package built: $(ls -lh function.zip | awk '{print $5}')"
 		}
 
 		// Custom policy for Bedrock, DynamoDB, SNS, and resource tag operations
-		customPolicy := pulumi.All(rulesTable.Arn, snsTopic.Arn).ApplyT(func(args []any) (string, error) {
+		policyInputs := []any{rulesTable.Arn, aliasesTable.Arn, snsTopic.Arn}
+		if enableRemediation {
+			policyInputs = append(policyInputs, highRiskTypesTable.Arn)
+		}
+
+		customPolicy := pulumi.All(policyInputs...).ApplyT(func(args []any) (string, error) {
 			tableArn := args[0].(string)
-			topicArn := args[1].(string)
-			policy, _ := json.Marshal(map[string]any{
-				"Version": "2012-10-17",
-				"Statement": []map[string]any{
-					{
-						"Sid":    "BedrockInvoke",
-						"Effect": "Allow",
-						"Action": []string{
-							"bedrock:InvokeModel",
-							"bedrock:InvokeModelWithResponseStream",
-						},
-						"Resource": "*",
+			aliasesTableArn := args[1].(string)
+			topicArn := args[2].(string)
+			statements := []map[string]any{
+				{
+					"Sid":    "BedrockInvoke",
+					"Effect": "Allow",
+					"Action": []string{
+						"bedrock:InvokeModel",
+						"bedrock:InvokeModelWithResponseStream",
 					},
-					{
-						"Sid":    "DynamoDBRead",
-						"Effect": "Allow",
-						"Action": []string{
-							"dynamodb:GetItem",
-							"dynamodb:Scan",
-							"dynamodb:Query",
-						},
-						"Resource": tableArn,
+					"Resource": "*",
+				},
+				{
+					"Sid":    "DynamoDBRead",
+					"Effect": "Allow",
+					"Action": []string{
+						"dynamodb:GetItem",
+						"dynamodb:Scan",
+						"dynamodb:Query",
 					},
-					{
-						"Sid":    "SNSPublish",
-						"Effect": "Allow",
-						"Action": []string{
-							"sns:Publish",
-						},
-						"Resource": topicArn,
+					"Resource": tableArn,
+				},
+				{
+					"Sid":    "AliasesTableRead",
+					"Effect": "Allow",
+					"Action": []string{
+						"dynamodb:GetItem",
+						"dynamodb:Scan",
 					},
-					{
-						"Sid":    "ResourceTagging",
-						"Effect": "Allow",
-						"Action": []string{
-							"ec2:DescribeTags",
-							"ec2:DescribeInstances",
-							"ec2:DescribeVolumes",
-							"s3:GetBucketTagging",
-							"s3:ListBucket",
-							"rds:DescribeDBInstances",
-							"rds:ListTagsForResource",
-							"lambda:GetFunction",
-							"lambda:ListTags",
-							"elasticloadbalancing:DescribeTags",
-							"autoscaling:DescribeTags",
-							"tag:GetResources",
-							"tag:GetTagKeys",
-							"tag:GetTagValues",
-						},
-						"Resource": "*",
+					"Resource": aliasesTableArn,
+				},
+				{
+					"Sid":    "SNSPublish",
+					"Effect": "Allow",
+					"Action": []string{
+						"sns:Publish",
 					},
+					"Resource": topicArn,
 				},
+				{
+					"Sid":    "ResourceTagging",
+					"Effect": "Allow",
+					"Action": []string{
+						"ec2:DescribeTags",
+						"ec2:DescribeInstances",
+						"ec2:DescribeVolumes",
+						"s3:GetBucketTagging",
+						"s3:ListBucket",
+						"rds:DescribeDBInstances",
+						"rds:ListTagsForResource",
+						"lambda:GetFunction",
+						"lambda:ListTags",
+						"elasticloadbalancing:DescribeTags",
+						"autoscaling:DescribeTags",
+						"tag:GetResources",
+						"tag:GetTagKeys",
+						"tag:GetTagValues",
+					},
+					"Resource": "*",
+				},
+			}
+			if enableRemediation {
+				highRiskTypesTableArn := args[3].(string)
+				statements = append(statements, map[string]any{
+					"Sid":    "HighRiskTypesRead",
+					"Effect": "Allow",
+					"Action": []string{
+						"dynamodb:GetItem",
+						"dynamodb:Scan",
+					},
+					"Resource": highRiskTypesTableArn,
+				})
+			}
+			policy, _ := json.Marshal(map[string]any{
+				"Version":   "2012-10-17",
+				"Statement": statements,
 			})
 			return string(policy), nil
 		}).(pulumi.StringOutput)
@@ -346,6 +619,21 @@ echo "Lambda package built: $(ls -lh function.zip | awk '{print $5}')"
 		}
 
 		// Create Lambda function
+		lambdaEnvVars := pulumi.StringMap{
+			"BEDROCK_MODEL_ID":     pulumi.String(bedrockModelId),
+			"RULES_TABLE_NAME":     rulesTable.Name,
+			"ALIASES_TABLE_NAME":   aliasesTable.Name,
+			"SNS_TOPIC_ARN":        snsTopic.Arn,
+			"IGNORE_TAG_KEYS":      pulumi.String(ignoreTagKeys),
+			"IGNORE_TAG_PREFIXES":  pulumi.String(ignoreTagPrefixes),
+			"SECURITY_HUB_ENABLED": pulumi.String(fmt.Sprintf("%t", enableSecurityHub)),
+			"LOG_LEVEL":            pulumi.String("INFO"),
+			"PYTHONPATH":           pulumi.String("/var/task"),
+		}
+		if enableRemediation {
+			lambdaEnvVars["HIGH_RISK_TYPES_TABLE_NAME"] = highRiskTypesTable.Name
+		}
+
 		lambdaFunc, err := lambda.NewFunction(ctx, "tag-compliance-checker", &lambda.FunctionArgs{
 			Name:       pulumi.String("tag-compliance-checker"),
 			Runtime:    pulumi.String("python3.12"),
@@ -358,13 +646,7 @@ echo "Lambda package built: $(ls -lh function.zip | awk '{print $5}')"
 			},
 			Code: pulumi.NewFileArchive("../../lambda/function.zip"),
 			Environment: &lambda.FunctionEnvironmentArgs{
-				Variables: pulumi.StringMap{
-					"BEDROCK_MODEL_ID": pulumi.String(bedrockModelId),
-					"RULES_TABLE_NAME": rulesTable.Name,
-					"SNS_TOPIC_ARN":    snsTopic.Arn,
-					"LOG_LEVEL":        pulumi.String("INFO"),
-					"PYTHONPATH":       pulumi.String("/var/task"),
-				},
+				Variables: lambdaEnvVars,
 			},
 			Tags: pulumi.StringMap{
 				"Project":   pulumi.String("TagCompliance"),
@@ -412,6 +694,53 @@ echo "Lambda package built: $(ls -lh function.zip | awk '{print $5}')"
 			return err
 		}
 
+		// Dead-letter queue for the primary event target, so a throttled Lambda/state machine
+		// or a transient events:PutEvents failure doesn't silently drop a compliance event
+		eventTargetDlq, err := sqs.NewQueue(ctx, "tag-compliance-event-dlq", &sqs.QueueArgs{
+			Name:                    pulumi.String("tag-compliance-event-dlq"),
+			MessageRetentionSeconds: pulumi.Int(1209600),
+			Tags: pulumi.StringMap{
+				"Project":   pulumi.String("TagCompliance"),
+				"ManagedBy": pulumi.String("Pulumi"),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		eventTargetDlqPolicy := pulumi.All(eventTargetDlq.Arn, eventRule.Arn).ApplyT(func(args []any) (string, error) {
+			queueArn := args[0].(string)
+			ruleArn := args[1].(string)
+			policy, _ := json.Marshal(map[string]any{
+				"Version": "2012-10-17",
+				"Statement": []map[string]any{
+					{
+						"Sid":    "AllowEventBridgeSendMessage",
+						"Effect": "Allow",
+						"Principal": map[string]any{
+							"Service": "events.amazonaws.com",
+						},
+						"Action":   "sqs:SendMessage",
+						"Resource": queueArn,
+						"Condition": map[string]any{
+							"ArnEquals": map[string]any{
+								"aws:SourceArn": ruleArn,
+							},
+						},
+					},
+				},
+			})
+			return string(policy), nil
+		}).(pulumi.StringOutput)
+
+		_, err = sqs.NewQueuePolicy(ctx, "tag-compliance-event-dlq-policy", &sqs.QueuePolicyArgs{
+			QueueUrl: eventTargetDlq.Url,
+			Policy:   eventTargetDlqPolicy,
+		})
+		if err != nil {
+			return err
+		}
+
 		// Grant EventBridge permission to invoke Lambda
 		_, err = lambda.NewPermission(ctx, "eventbridge-invoke-lambda", &lambda.PermissionArgs{
 			Action:    pulumi.String("lambda:InvokeFunction"),
@@ -423,17 +752,592 @@ echo "Lambda package built: $(ls -lh function.zip | awk '{print $5}')"
 			return err
 		}
 
-		// Create EventBridge target on custom bus
-		_, err = cloudwatch.NewEventTarget(ctx, "tag-compliance-target", &cloudwatch.EventTargetArgs{
-			Rule:         eventRule.Name,
-			EventBusName: eventBus.Name,
-			TargetId:     pulumi.String("tag-compliance-lambda"),
-			Arn:          lambdaFunc.Arn,
+		// Optionally provision an auto-remediation pipeline: checker -> remediator, with a
+		// manual approval gate for high-risk resource types.
+		var remediationStateMachine *sfn.StateMachine
+		var remediationApprovalTopic *sns.Topic
+		if enableRemediation {
+			remediationApprovalTopic, err = sns.NewTopic(ctx, "tag-compliance-remediation-approval", &sns.TopicArgs{
+				Name:        pulumi.String("tag-compliance-remediation-approval"),
+				DisplayName: pulumi.String("Tag Compliance Remediation Approval"),
+				Tags: pulumi.StringMap{
+					"Project":   pulumi.String("TagCompliance"),
+					"ManagedBy": pulumi.String("Pulumi"),
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			if approvalEmail != "" {
+				_, err = sns.NewTopicSubscription(ctx, "tag-compliance-remediation-approval-email", &sns.TopicSubscriptionArgs{
+					Topic:    remediationApprovalTopic.Arn,
+					Protocol: pulumi.String("email"),
+					Endpoint: pulumi.String(approvalEmail),
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			remediatorRole, err := iam.NewRole(ctx, "tag-compliance-remediator-role", &iam.RoleArgs{
+				Name:             pulumi.String("tag-compliance-remediator-role"),
+				AssumeRolePolicy: pulumi.String(string(assumeRolePolicy)),
+				Tags: pulumi.StringMap{
+					"Project":   pulumi.String("TagCompliance"),
+					"ManagedBy": pulumi.String("Pulumi"),
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = iam.NewRolePolicyAttachment(ctx, "remediator-basic-execution", &iam.RolePolicyAttachmentArgs{
+				Role:      remediatorRole.Name,
+				PolicyArn: pulumi.String("arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"),
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = iam.NewRolePolicy(ctx, "tag-compliance-remediator-policy", &iam.RolePolicyArgs{
+				Role: remediatorRole.Name,
+				Policy: highRiskTypesTable.Arn.ApplyT(func(tableArn string) (string, error) {
+					policy, _ := json.Marshal(map[string]any{
+						"Version": "2012-10-17",
+						"Statement": []map[string]any{
+							{
+								"Sid":    "ApplyMissingTags",
+								"Effect": "Allow",
+								"Action": []string{
+									"tag:TagResources",
+									"ec2:CreateTags",
+									"s3:PutBucketTagging",
+									"rds:AddTagsToResource",
+									"lambda:TagResource",
+									"elasticloadbalancing:AddTags",
+									"autoscaling:CreateOrUpdateTags",
+								},
+								"Resource": "*",
+							},
+							{
+								"Sid":      "HighRiskTypesRead",
+								"Effect":   "Allow",
+								"Action":   []string{"dynamodb:GetItem", "dynamodb:Scan"},
+								"Resource": tableArn,
+							},
+						},
+					})
+					return string(policy), nil
+				}).(pulumi.StringOutput),
+			})
+			if err != nil {
+				return err
+			}
+
+			remediatorFunc, err := lambda.NewFunction(ctx, "tag-compliance-remediator", &lambda.FunctionArgs{
+				Name:       pulumi.String("tag-compliance-remediator"),
+				Runtime:    pulumi.String("python3.12"),
+				Handler:    pulumi.String("remediator.lambda_handler"),
+				Role:       remediatorRole.Arn,
+				MemorySize: pulumi.Int(lambdaMemory),
+				Timeout:    pulumi.Int(lambdaTimeout),
+				Architectures: pulumi.StringArray{
+					pulumi.String(lambdaArchitecture),
+				},
+				Code: pulumi.NewFileArchive("../../lambda/function.zip"),
+				Environment: &lambda.FunctionEnvironmentArgs{
+					Variables: pulumi.StringMap{
+						"HIGH_RISK_TYPES_TABLE_NAME": highRiskTypesTable.Name,
+						"LOG_LEVEL":                  pulumi.String("INFO"),
+						"PYTHONPATH":                 pulumi.String("/var/task"),
+					},
+				},
+				Tags: pulumi.StringMap{
+					"Project":   pulumi.String("TagCompliance"),
+					"ManagedBy": pulumi.String("Pulumi"),
+				},
+			}, pulumi.DependsOn([]pulumi.Resource{logGroup, buildLambda}))
+			if err != nil {
+				return err
+			}
+
+			sfnAssumeRolePolicy, _ := json.Marshal(map[string]any{
+				"Version": "2012-10-17",
+				"Statement": []map[string]any{
+					{
+						"Action": "sts:AssumeRole",
+						"Effect": "Allow",
+						"Principal": map[string]any{
+							"Service": "states.amazonaws.com",
+						},
+					},
+				},
+			})
+
+			stateMachineRole, err := iam.NewRole(ctx, "tag-compliance-state-machine-role", &iam.RoleArgs{
+				Name:             pulumi.String("tag-compliance-state-machine-role"),
+				AssumeRolePolicy: pulumi.String(string(sfnAssumeRolePolicy)),
+				Tags: pulumi.StringMap{
+					"Project":   pulumi.String("TagCompliance"),
+					"ManagedBy": pulumi.String("Pulumi"),
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = iam.NewRolePolicy(ctx, "tag-compliance-state-machine-policy", &iam.RolePolicyArgs{
+				Role: stateMachineRole.Name,
+				Policy: pulumi.All(lambdaFunc.Arn, remediatorFunc.Arn, remediationApprovalTopic.Arn).ApplyT(func(args []any) (string, error) {
+					checkerArn := args[0].(string)
+					remediatorArn := args[1].(string)
+					topicArn := args[2].(string)
+					policy, _ := json.Marshal(map[string]any{
+						"Version": "2012-10-17",
+						"Statement": []map[string]any{
+							{
+								"Sid":      "InvokeLambdas",
+								"Effect":   "Allow",
+								"Action":   []string{"lambda:InvokeFunction"},
+								"Resource": []string{checkerArn, remediatorArn},
+							},
+							{
+								"Sid":      "PublishApproval",
+								"Effect":   "Allow",
+								"Action":   []string{"sns:Publish"},
+								"Resource": topicArn,
+							},
+						},
+					})
+					return string(policy), nil
+				}).(pulumi.StringOutput),
+			})
+			if err != nil {
+				return err
+			}
+
+			stateMachineDefinition := pulumi.All(lambdaFunc.Arn, remediatorFunc.Arn, remediationApprovalTopic.Arn).ApplyT(func(args []any) (string, error) {
+				checkerArn := args[0].(string)
+				remediatorArn := args[1].(string)
+				topicArn := args[2].(string)
+				definition, _ := json.Marshal(map[string]any{
+					"Comment": "Check tag compliance, remediate automatically, and require approval for high-risk resource types",
+					"StartAt": "CheckCompliance",
+					"States": map[string]any{
+						"CheckCompliance": map[string]any{
+							"Type":     "Task",
+							"Resource": checkerArn,
+							"Next":     "IsNonCompliant",
+						},
+						"IsNonCompliant": map[string]any{
+							"Type": "Choice",
+							"Choices": []map[string]any{
+								{
+									"Variable":     "$.status",
+									"StringEquals": "non_compliant",
+									"Next":         "IsHighRisk",
+								},
+							},
+							"Default": "Compliant",
+						},
+						"IsHighRisk": map[string]any{
+							"Type": "Choice",
+							"Choices": []map[string]any{
+								{
+									"Variable":      "$.highRisk",
+									"BooleanEquals": true,
+									"Next":          "WaitForApproval",
+								},
+							},
+							"Default": "Remediate",
+						},
+						"WaitForApproval": map[string]any{
+							"Type":     "Task",
+							"Resource": "arn:aws:states:::sns:publish.waitForTaskToken",
+							"Parameters": map[string]any{
+								"TopicArn": topicArn,
+								"Message.$": "$",
+								"MessageAttributes": map[string]any{
+									"taskToken": map[string]any{
+										"DataType":  "String",
+										"StringValue.$": "$$.Task.Token",
+									},
+								},
+							},
+							"Next": "Remediate",
+						},
+						"Remediate": map[string]any{
+							"Type":     "Task",
+							"Resource": remediatorArn,
+							"End":      true,
+						},
+						"Compliant": map[string]any{
+							"Type": "Pass",
+							"End":  true,
+						},
+					},
+				})
+				return string(definition), nil
+			}).(pulumi.StringOutput)
+
+			remediationStateMachine, err = sfn.NewStateMachine(ctx, "tag-compliance-remediation", &sfn.StateMachineArgs{
+				Name:       pulumi.String("tag-compliance-remediation"),
+				RoleArn:    stateMachineRole.Arn,
+				Definition: stateMachineDefinition,
+				Tags: pulumi.StringMap{
+					"Project":   pulumi.String("TagCompliance"),
+					"ManagedBy": pulumi.String("Pulumi"),
+				},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// Create EventBridge target on custom bus. When remediation is enabled the target is the
+		// state machine (so non-compliant results can be remediated/approved); otherwise it invokes
+		// the checker Lambda directly.
+		if enableRemediation {
+			eventBridgeSfnAssumeRolePolicy, _ := json.Marshal(map[string]any{
+				"Version": "2012-10-17",
+				"Statement": []map[string]any{
+					{
+						"Action": "sts:AssumeRole",
+						"Effect": "Allow",
+						"Principal": map[string]any{
+							"Service": "events.amazonaws.com",
+						},
+					},
+				},
+			})
+
+			eventBridgeSfnRole, err := iam.NewRole(ctx, "tag-compliance-eventbridge-sfn-role", &iam.RoleArgs{
+				Name:             pulumi.String("tag-compliance-eventbridge-sfn-role"),
+				AssumeRolePolicy: pulumi.String(string(eventBridgeSfnAssumeRolePolicy)),
+				Tags: pulumi.StringMap{
+					"Project":   pulumi.String("TagCompliance"),
+					"ManagedBy": pulumi.String("Pulumi"),
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = iam.NewRolePolicy(ctx, "tag-compliance-eventbridge-sfn-policy", &iam.RolePolicyArgs{
+				Role: eventBridgeSfnRole.Name,
+				Policy: remediationStateMachine.Arn.ApplyT(func(smArn string) (string, error) {
+					policy, _ := json.Marshal(map[string]any{
+						"Version": "2012-10-17",
+						"Statement": []map[string]any{
+							{
+								"Sid":      "StartRemediationExecution",
+								"Effect":   "Allow",
+								"Action":   []string{"states:StartExecution"},
+								"Resource": smArn,
+							},
+						},
+					})
+					return string(policy), nil
+				}).(pulumi.StringOutput),
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = cloudwatch.NewEventTarget(ctx, "tag-compliance-target", &cloudwatch.EventTargetArgs{
+				Rule:         eventRule.Name,
+				EventBusName: eventBus.Name,
+				TargetId:     pulumi.String("tag-compliance-remediation"),
+				Arn:          remediationStateMachine.Arn,
+				RoleArn:      eventBridgeSfnRole.Arn,
+				DeadLetterConfig: &cloudwatch.EventTargetDeadLetterConfigArgs{
+					Arn: eventTargetDlq.Arn,
+				},
+				RetryPolicy: &cloudwatch.EventTargetRetryPolicyArgs{
+					MaximumRetryAttempts:     pulumi.Int(eventRetries),
+					MaximumEventAgeInSeconds: pulumi.Int(eventMaxAgeSeconds),
+				},
+			})
+			if err != nil {
+				return err
+			}
+		} else {
+			_, err = cloudwatch.NewEventTarget(ctx, "tag-compliance-target", &cloudwatch.EventTargetArgs{
+				Rule:         eventRule.Name,
+				EventBusName: eventBus.Name,
+				TargetId:     pulumi.String("tag-compliance-lambda"),
+				Arn:          lambdaFunc.Arn,
+				DeadLetterConfig: &cloudwatch.EventTargetDeadLetterConfigArgs{
+					Arn: eventTargetDlq.Arn,
+				},
+				RetryPolicy: &cloudwatch.EventTargetRetryPolicyArgs{
+					MaximumRetryAttempts:     pulumi.Int(eventRetries),
+					MaximumEventAgeInSeconds: pulumi.Int(eventMaxAgeSeconds),
+				},
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// Create SQS dead-letter queue and work-buffer queue for periodic scan jobs
+		scanQueueDlq, err := sqs.NewQueue(ctx, "tag-compliance-scan-dlq", &sqs.QueueArgs{
+			Name:                    pulumi.String("tag-compliance-scan-dlq"),
+			MessageRetentionSeconds: pulumi.Int(1209600),
+			Tags: pulumi.StringMap{
+				"Project":   pulumi.String("TagCompliance"),
+				"ManagedBy": pulumi.String("Pulumi"),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		scanQueueRedrivePolicy := scanQueueDlq.Arn.ApplyT(func(dlqArn string) (string, error) {
+			policy, _ := json.Marshal(map[string]any{
+				"deadLetterTargetArn": dlqArn,
+				"maxReceiveCount":     scanQueueMaxReceives,
+			})
+			return string(policy), nil
+		}).(pulumi.StringOutput)
+
+		scanQueue, err := sqs.NewQueue(ctx, "tag-compliance-scan-queue", &sqs.QueueArgs{
+			Name:                     pulumi.String("tag-compliance-scan-queue"),
+			VisibilityTimeoutSeconds: pulumi.Int(lambdaTimeout * 6),
+			RedrivePolicy:            scanQueueRedrivePolicy,
+			Tags: pulumi.StringMap{
+				"Project":   pulumi.String("TagCompliance"),
+				"ManagedBy": pulumi.String("Pulumi"),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		// Grant the Lambda role permission to consume the scan queue
+		_, err = iam.NewRolePolicy(ctx, "tag-compliance-scan-queue-policy", &iam.RolePolicyArgs{
+			Role: lambdaRole.Name,
+			Policy: scanQueue.Arn.ApplyT(func(queueArn string) (string, error) {
+				policy, _ := json.Marshal(map[string]any{
+					"Version": "2012-10-17",
+					"Statement": []map[string]any{
+						{
+							"Sid":    "ScanQueueConsume",
+							"Effect": "Allow",
+							"Action": []string{
+								"sqs:ReceiveMessage",
+								"sqs:DeleteMessage",
+								"sqs:GetQueueAttributes",
+							},
+							"Resource": queueArn,
+						},
+					},
+				})
+				return string(policy), nil
+			}).(pulumi.StringOutput),
+		})
+		if err != nil {
+			return err
+		}
+
+		// Wire the scan queue as a Lambda event source so scan jobs drain through it
+		_, err = lambda.NewEventSourceMapping(ctx, "tag-compliance-scan-queue-mapping", &lambda.EventSourceMappingArgs{
+			EventSourceArn: scanQueue.Arn,
+			FunctionName:   lambdaFunc.Name,
+			BatchSize:      pulumi.Int(10),
+		})
+		if err != nil {
+			return err
+		}
+
+		// Scheduled EventRule that fans out a periodic full scan across spoke accounts onto the scan queue
+		scanScheduleRule, err := cloudwatch.NewEventRule(ctx, "tag-compliance-scan-schedule", &cloudwatch.EventRuleArgs{
+			Name:               pulumi.String("tag-compliance-scan-schedule"),
+			Description:        pulumi.String("Periodically trigger a scheduled scan to catch resources missed by the event-driven path"),
+			ScheduleExpression: pulumi.String(scanSchedule),
+			Tags: pulumi.StringMap{
+				"Project":   pulumi.String("TagCompliance"),
+				"ManagedBy": pulumi.String("Pulumi"),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		// Grant EventBridge permission to enqueue scheduled scan jobs onto the scan queue
+		scanQueuePolicy := pulumi.All(scanQueue.Arn, scanScheduleRule.Arn).ApplyT(func(args []any) (string, error) {
+			queueArn := args[0].(string)
+			ruleArn := args[1].(string)
+			policy, _ := json.Marshal(map[string]any{
+				"Version": "2012-10-17",
+				"Statement": []map[string]any{
+					{
+						"Sid":    "AllowEventBridgeSendMessage",
+						"Effect": "Allow",
+						"Principal": map[string]any{
+							"Service": "events.amazonaws.com",
+						},
+						"Action":   "sqs:SendMessage",
+						"Resource": queueArn,
+						"Condition": map[string]any{
+							"ArnEquals": map[string]any{
+								"aws:SourceArn": ruleArn,
+							},
+						},
+					},
+				},
+			})
+			return string(policy), nil
+		}).(pulumi.StringOutput)
+
+		_, err = sqs.NewQueuePolicy(ctx, "tag-compliance-scan-queue-policy-attachment", &sqs.QueuePolicyArgs{
+			QueueUrl: scanQueue.Url,
+			Policy:   scanQueuePolicy,
 		})
 		if err != nil {
 			return err
 		}
 
+		// Enqueue one paginated scan job per configured spoke account, so a single schedule tick
+		// doesn't dump every spoke's inventory onto the Lambda as a single message and blow through
+		// its concurrency on large estates. With no spoke accounts configured, fall back to a
+		// single job scanning the hub account itself.
+		var scanTargetAccounts []string
+		if spokeAccountIds != "" {
+			for _, accountId := range strings.Split(spokeAccountIds, ",") {
+				accountId = strings.TrimSpace(accountId)
+				if accountId != "" {
+					scanTargetAccounts = append(scanTargetAccounts, accountId)
+				}
+			}
+		}
+		if len(scanTargetAccounts) == 0 {
+			scanTargetAccounts = []string{currentAccountId}
+		}
+
+		for _, accountId := range scanTargetAccounts {
+			_, err = cloudwatch.NewEventTarget(ctx, "tag-compliance-scan-target-"+accountId, &cloudwatch.EventTargetArgs{
+				Rule:     scanScheduleRule.Name,
+				TargetId: pulumi.String("tag-compliance-scan-queue-" + accountId),
+				Arn:      scanQueue.Arn,
+				Input:    pulumi.String(fmt.Sprintf(`{"scanType":"full","accountId":"%s"}`, accountId)),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// CloudWatch dashboard summarizing checker health and compliance posture. The Lambda emits
+		// EMF-formatted custom metrics under the TagCompliance namespace (NonCompliantCount,
+		// dimensioned by AccountId/ResourceType/RuleId) and Bedrock latency, which this renders.
+		dashboardBody := pulumi.All(lambdaFunc.Name, scanQueueDlq.Name, eventTargetDlq.Name).ApplyT(func(args []any) (string, error) {
+			funcName := args[0].(string)
+			scanDlqName := args[1].(string)
+			eventDlqName := args[2].(string)
+			body, _ := json.Marshal(map[string]any{
+				"widgets": []map[string]any{
+					{
+						"type": "metric",
+						"properties": map[string]any{
+							"title": "Checker invocations / errors",
+							"metrics": []any{
+								[]any{"AWS/Lambda", "Invocations", "FunctionName", funcName},
+								[]any{"AWS/Lambda", "Errors", "FunctionName", funcName},
+							},
+							"stat":   "Sum",
+							"period": 300,
+						},
+					},
+					{
+						"type": "metric",
+						"properties": map[string]any{
+							"title":   "Bedrock invocation latency (EMF)",
+							"metrics": []any{[]any{"TagCompliance", "BedrockLatencyMs"}},
+							"stat":    "Average",
+							"period":  300,
+						},
+					},
+					{
+						"type": "metric",
+						"properties": map[string]any{
+							"title":   "Non-compliant resources by account/type",
+							"metrics": []any{[]any{"TagCompliance", "NonCompliantCount", "AccountId", "*", "ResourceType", "*", "RuleId", "*"}},
+							"stat":    "Sum",
+							"period":  300,
+						},
+					},
+					{
+						"type": "metric",
+						"properties": map[string]any{
+							"title": "Dead-letter queue depth",
+							"metrics": []any{
+								[]any{"AWS/SQS", "ApproximateNumberOfMessagesVisible", "QueueName", scanDlqName},
+								[]any{"AWS/SQS", "ApproximateNumberOfMessagesVisible", "QueueName", eventDlqName},
+							},
+							"stat":   "Maximum",
+							"period": 300,
+						},
+					},
+				},
+			})
+			return string(body), nil
+		}).(pulumi.StringOutput)
+
+		dashboard, err := cloudwatch.NewDashboard(ctx, "tag-compliance-dashboard", &cloudwatch.DashboardArgs{
+			DashboardName: pulumi.String("TagCompliance"),
+			DashboardBody: dashboardBody,
+		})
+		if err != nil {
+			return err
+		}
+
+		// findingSeverityMapping maps a rule's configured severity to the ASFF severity label used
+		// when posting non-compliant resources to Security Hub.
+		findingSeverityMapping := pulumi.StringMap{
+			"critical": pulumi.String("CRITICAL"),
+			"high":     pulumi.String("HIGH"),
+			"medium":   pulumi.String("MEDIUM"),
+			"low":      pulumi.String("LOW"),
+		}
+
+		// Optionally post each non-compliant resource to Security Hub as an ASFF finding
+		if enableSecurityHub {
+			// ProductSubscription requires Security Hub already enabled on the account, so enable
+			// it here rather than assuming the operator has done so out of band.
+			securityHubAccount, err := securityhub.NewAccount(ctx, "tag-compliance-security-hub-account", &securityhub.AccountArgs{})
+			if err != nil {
+				return err
+			}
+
+			_, err = securityhub.NewProductSubscription(ctx, "tag-compliance-security-hub-subscription", &securityhub.ProductSubscriptionArgs{
+				ProductArn: pulumi.Sprintf("arn:aws:securityhub:%s::product/aws/default", region),
+			}, pulumi.DependsOn([]pulumi.Resource{securityHubAccount}))
+			if err != nil {
+				return err
+			}
+
+			securityHubPolicy, _ := json.Marshal(map[string]any{
+				"Version": "2012-10-17",
+				"Statement": []map[string]any{
+					{
+						"Sid":      "SecurityHubImportFindings",
+						"Effect":   "Allow",
+						"Action":   []string{"securityhub:BatchImportFindings"},
+						"Resource": "*",
+					},
+				},
+			})
+
+			_, err = iam.NewRolePolicy(ctx, "tag-compliance-security-hub-policy", &iam.RolePolicyArgs{
+				Role:   lambdaRole.Name,
+				Policy: pulumi.String(string(securityHubPolicy)),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
 		// Export hub outputs
 		ctx.Export("region", pulumi.String(region))
 		ctx.Export("accountId", pulumi.String(currentAccountId))
@@ -443,12 +1347,30 @@ echo "Lambda package built: $(ls -lh function.zip | awk '{print $5}')"
 		ctx.Export("lambdaFunctionArn", lambdaFunc.Arn)
 		ctx.Export("dynamoDBTableName", rulesTable.Name)
 		ctx.Export("dynamoDBTableArn", rulesTable.Arn)
+		ctx.Export("aliasesTableName", aliasesTable.Name)
+		ctx.Export("aliasesTableArn", aliasesTable.Arn)
 		ctx.Export("snsTopicName", snsTopic.Name)
 		ctx.Export("snsTopicArn", snsTopic.Arn)
 		ctx.Export("eventRuleName", eventRule.Name)
 		ctx.Export("eventRuleArn", eventRule.Arn)
 		ctx.Export("logGroupName", logGroup.Name)
 		ctx.Export("bedrockModelId", pulumi.String(bedrockModelId))
+		ctx.Export("scanQueueArn", scanQueue.Arn)
+		ctx.Export("scanQueueUrl", scanQueue.Url)
+		ctx.Export("scanQueueDlqArn", scanQueueDlq.Arn)
+		ctx.Export("scanScheduleRuleArn", scanScheduleRule.Arn)
+		ctx.Export("eventTargetDlqArn", eventTargetDlq.Arn)
+		ctx.Export("eventTargetDlqUrl", eventTargetDlq.Url)
+		ctx.Export("eventArchiveArn", eventArchive.Arn)
+		ctx.Export("dashboardUrl", pulumi.Sprintf("https://%s.console.aws.amazon.com/cloudwatch/home?region=%s#dashboards:name=%s", region, region, dashboard.DashboardName))
+		ctx.Export("findingSeverityMapping", findingSeverityMapping)
+		if deploySpokeStackSet {
+			ctx.Export("spokeStackSetName", spokeStackSet.Name)
+		}
+		if enableRemediation {
+			ctx.Export("remediationStateMachineArn", remediationStateMachine.Arn)
+			ctx.Export("remediationApprovalTopicArn", remediationApprovalTopic.Arn)
+		}
 
 		return nil
 	})
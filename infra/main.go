@@ -3,11 +3,16 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/apigatewayv2"
 	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/cloudwatch"
 	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/dynamodb"
 	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/iam"
 	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/lambda"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/sns"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/sqs"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
 )
@@ -48,6 +53,62 @@ func main() {
 			lambdaTimeout = 60
 		}
 
+		// Regions to fan the EventBridge rule + target out to. The primary region, which hosts the
+		// central Lambda + DynamoDB rules table, gets a rule only if it is listed; an empty list
+		// means the primary region alone.
+		var regions []string
+		if regionsCsv := cfg.Get("regions"); regionsCsv != "" {
+			for _, r := range strings.Split(regionsCsv, ",") {
+				r = strings.TrimSpace(r)
+				if r != "" {
+					regions = append(regions, r)
+				}
+			}
+		}
+		if len(regions) == 0 {
+			regions = []string{region}
+		}
+		deploymentMode := cfg.Get("deploymentMode")
+		if deploymentMode == "" {
+			deploymentMode = "central"
+		}
+		if deploymentMode == "central" {
+			for _, r := range regions {
+				if r != region {
+					return fmt.Errorf("tagCompliance:deploymentMode \"central\" only supports a single region: "+
+						"tagCompliance:regions includes %q alongside the primary region %q; EventBridge targets "+
+						"must live in the same region as their rule, so a central Lambda can't be invoked from "+
+						"another region's rule. Use deploymentMode \"replicated\" to deploy a Lambda per region", r, region)
+				}
+			}
+		}
+		enableApi := cfg.GetBool("enableApi")
+		scanSchedule := cfg.Get("scanSchedule")
+		if scanSchedule == "" {
+			scanSchedule = "rate(24 hours)"
+		}
+
+		// Spoke accounts to assume into for cross-account tag checking, e.g.
+		// [{"accountId":"111111111111","roleName":"tag-compliance-spoke-trust"}]
+		var targetAccounts []struct {
+			AccountId string `json:"accountId"`
+			RoleName  string `json:"roleName"`
+		}
+		targetAccountsEnv := cfg.Get("targetAccounts")
+		if targetAccountsEnv != "" {
+			if err := json.Unmarshal([]byte(targetAccountsEnv), &targetAccounts); err != nil {
+				return fmt.Errorf("invalid tagCompliance:targetAccounts config: %w", err)
+			}
+		} else {
+			targetAccountsEnv = "[]"
+		}
+
+		alarmEmail := cfg.Get("alarmEmail")
+		nonComplianceThreshold := cfg.GetFloat64("nonComplianceThreshold")
+		if nonComplianceThreshold == 0 {
+			nonComplianceThreshold = 10
+		}
+
 		// Create DynamoDB table for tag rules
 		rulesTable, err := dynamodb.NewTable(ctx, "tag-compliance-rules", &dynamodb.TableArgs{
 			Name:        pulumi.String("TagComplianceRules"),
@@ -81,6 +142,20 @@ func main() {
 			return err
 		}
 
+		// Dead-letter queue for crashed/undelivered scan invocations, so they're observable and
+		// replayable instead of silently disappearing
+		scanDlq, err := sqs.NewQueue(ctx, "tag-compliance-scan-dlq", &sqs.QueueArgs{
+			Name:                    pulumi.String("tag-compliance-scan-dlq"),
+			MessageRetentionSeconds: pulumi.Int(1209600),
+			Tags: pulumi.StringMap{
+				"Project":   pulumi.String("TagCompliance"),
+				"ManagedBy": pulumi.String("Pulumi"),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
 		// Create IAM role for Lambda
 		assumeRolePolicy, _ := json.Marshal(map[string]interface{}{
 			"Version": "2012-10-17",
@@ -116,61 +191,145 @@ func main() {
 			return err
 		}
 
-		// Custom policy for Bedrock, DynamoDB, Secrets Manager, and resource tag operations
-		customPolicy := pulumi.All(rulesTable.Arn).ApplyT(func(args []interface{}) (string, error) {
-			tableArn := args[0].(string)
-			policy, _ := json.Marshal(map[string]interface{}{
-				"Version": "2012-10-17",
-				"Statement": []map[string]interface{}{
-					{
-						"Sid":    "BedrockInvoke",
-						"Effect": "Allow",
-						"Action": []string{
-							"bedrock:InvokeModel",
-							"bedrock:InvokeModelWithResponseStream",
+		// Allow the Lambda to deliver failed invocation records to its dead-letter queue
+		_, err = iam.NewRolePolicy(ctx, "tag-compliance-scan-dlq-policy", &iam.RolePolicyArgs{
+			Role: lambdaRole.Name,
+			Policy: scanDlq.Arn.ApplyT(func(queueArn string) (string, error) {
+				policy, _ := json.Marshal(map[string]interface{}{
+					"Version": "2012-10-17",
+					"Statement": []map[string]interface{}{
+						{
+							"Sid":      "ScanDlqSend",
+							"Effect":   "Allow",
+							"Action":   []string{"sqs:SendMessage"},
+							"Resource": queueArn,
 						},
-						"Resource": "*",
 					},
-					{
-						"Sid":    "DynamoDBRead",
-						"Effect": "Allow",
-						"Action": []string{
-							"dynamodb:GetItem",
-							"dynamodb:Scan",
-							"dynamodb:Query",
-						},
-						"Resource": tableArn,
+				})
+				return string(policy), nil
+			}).(pulumi.StringOutput),
+		})
+		if err != nil {
+			return err
+		}
+
+		// SNS topic backing the non-compliance alarm; subscribed to alarmEmail when configured
+		alarmTopic, err := sns.NewTopic(ctx, "tag-compliance-alarm-topic", &sns.TopicArgs{
+			Name:        pulumi.String("tag-compliance-kpi-alarms"),
+			DisplayName: pulumi.String("Tag Compliance KPI Alarms"),
+			Tags: pulumi.StringMap{
+				"Project":   pulumi.String("TagCompliance"),
+				"ManagedBy": pulumi.String("Pulumi"),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if alarmEmail != "" {
+			_, err = sns.NewTopicSubscription(ctx, "tag-compliance-alarm-email", &sns.TopicSubscriptionArgs{
+				Topic:    alarmTopic.Arn,
+				Protocol: pulumi.String("email"),
+				Endpoint: pulumi.String(alarmEmail),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// Custom policy for Bedrock, DynamoDB, Secrets Manager, and resource tag operations
+		customPolicy := pulumi.All(rulesTable.Arn, alarmTopic.Arn).ApplyT(func(args []interface{}) (string, error) {
+			tableArn := args[0].(string)
+			dynamoDBActions := []string{
+				"dynamodb:GetItem",
+				"dynamodb:Scan",
+				"dynamodb:Query",
+			}
+			if enableApi {
+				dynamoDBActions = append(dynamoDBActions,
+					"dynamodb:PutItem",
+					"dynamodb:UpdateItem",
+					"dynamodb:DeleteItem",
+				)
+			}
+			statements := []map[string]interface{}{
+				{
+					"Sid":    "BedrockInvoke",
+					"Effect": "Allow",
+					"Action": []string{
+						"bedrock:InvokeModel",
+						"bedrock:InvokeModelWithResponseStream",
 					},
-					{
-						"Sid":    "SecretsManagerRead",
-						"Effect": "Allow",
-						"Action": []string{
-							"secretsmanager:GetSecretValue",
-						},
-						"Resource": fmt.Sprintf("arn:aws:secretsmanager:%s:*:secret:%s*", region, larkSecretName),
+					"Resource": "*",
+				},
+				{
+					"Sid":      "DynamoDBRead",
+					"Effect":   "Allow",
+					"Action":   dynamoDBActions,
+					"Resource": tableArn,
+				},
+				{
+					"Sid":    "SecretsManagerRead",
+					"Effect": "Allow",
+					"Action": []string{
+						"secretsmanager:GetSecretValue",
 					},
-					{
-						"Sid":    "ResourceTagging",
-						"Effect": "Allow",
-						"Action": []string{
-							"ec2:DescribeTags",
-							"ec2:DescribeInstances",
-							"ec2:DescribeVolumes",
-							"s3:GetBucketTagging",
-							"s3:ListBucket",
-							"rds:DescribeDBInstances",
-							"rds:ListTagsForResource",
-							"lambda:GetFunction",
-							"lambda:ListTags",
-							"elasticloadbalancing:DescribeTags",
-							"autoscaling:DescribeTags",
-							"tag:GetResources",
-							"tag:GetTagKeys",
-							"tag:GetTagValues",
-						},
-						"Resource": "*",
+					"Resource": fmt.Sprintf("arn:aws:secretsmanager:%s:*:secret:%s*", region, larkSecretName),
+				},
+				{
+					"Sid":    "ResourceTagging",
+					"Effect": "Allow",
+					"Action": []string{
+						"ec2:DescribeTags",
+						"ec2:DescribeInstances",
+						"ec2:DescribeVolumes",
+						"s3:GetBucketTagging",
+						"s3:ListBucket",
+						"rds:DescribeDBInstances",
+						"rds:ListTagsForResource",
+						"lambda:GetFunction",
+						"lambda:ListTags",
+						"elasticloadbalancing:DescribeTags",
+						"autoscaling:DescribeTags",
+						"tag:GetResources",
+						"tag:GetTagKeys",
+						"tag:GetTagValues",
 					},
+					"Resource": "*",
+				},
+			}
+
+			if len(targetAccounts) > 0 {
+				var spokeRoleArns []string
+				for _, target := range targetAccounts {
+					spokeRoleArns = append(spokeRoleArns, fmt.Sprintf("arn:aws:iam::%s:role/%s", target.AccountId, target.RoleName))
+				}
+				statements = append(statements, map[string]interface{}{
+					"Sid":      "AssumeSpokeRoles",
+					"Effect":   "Allow",
+					"Action":   []string{"sts:AssumeRole"},
+					"Resource": spokeRoleArns,
+				})
+			}
+
+			statements = append(statements, map[string]interface{}{
+				"Sid":    "PutComplianceMetrics",
+				"Effect": "Allow",
+				"Action": []string{
+					"cloudwatch:PutMetricData",
 				},
+				"Resource": "*",
+			})
+			statements = append(statements, map[string]interface{}{
+				"Sid":      "PublishAlarmNotifications",
+				"Effect":   "Allow",
+				"Action":   []string{"sns:Publish"},
+				"Resource": args[1].(string),
+			})
+
+			policy, _ := json.Marshal(map[string]interface{}{
+				"Version":   "2012-10-17",
+				"Statement": statements,
 			})
 			return string(policy), nil
 		}).(pulumi.StringOutput)
@@ -200,10 +359,14 @@ func main() {
 					"BEDROCK_MODEL_ID":  pulumi.String(bedrockModelId),
 					"RULES_TABLE_NAME":  rulesTable.Name,
 					"LARK_SECRET_NAME":  pulumi.String(larkSecretName),
+					"TARGET_ACCOUNTS":   pulumi.String(targetAccountsEnv),
 					"LOG_LEVEL":         pulumi.String("INFO"),
 					"PYTHONPATH":        pulumi.String("/var/task"),
 				},
 			},
+			DeadLetterConfig: &lambda.FunctionDeadLetterConfigArgs{
+				TargetArn: scanDlq.Arn,
+			},
 			Tags: pulumi.StringMap{
 				"Project": pulumi.String("TagCompliance"),
 				"ManagedBy": pulumi.String("Pulumi"),
@@ -213,6 +376,64 @@ func main() {
 			return err
 		}
 
+		// Optionally front the checker Lambda with an HTTP API so operators can request a
+		// synchronous compliance verdict and CRUD the rules table without touching DynamoDB
+		// directly: POST /check, GET /rules, PUT /rules/{ruleId}.
+		var httpApi *apigatewayv2.Api
+		if enableApi {
+			httpApi, err = apigatewayv2.NewApi(ctx, "tag-compliance-api", &apigatewayv2.ApiArgs{
+				Name:         pulumi.String("tag-compliance-api"),
+				ProtocolType: pulumi.String("HTTP"),
+				Tags: pulumi.StringMap{
+					"Project":   pulumi.String("TagCompliance"),
+					"ManagedBy": pulumi.String("Pulumi"),
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			apiIntegration, err := apigatewayv2.NewIntegration(ctx, "tag-compliance-api-integration", &apigatewayv2.IntegrationArgs{
+				ApiId:                httpApi.ID(),
+				IntegrationType:      pulumi.String("AWS_PROXY"),
+				IntegrationUri:       lambdaFunc.InvokeArn,
+				PayloadFormatVersion: pulumi.String("2.0"),
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, route := range []string{"POST /check", "GET /rules", "PUT /rules/{ruleId}"} {
+				_, err = apigatewayv2.NewRoute(ctx, "tag-compliance-api-route-"+strings.ReplaceAll(route, " ", "-"), &apigatewayv2.RouteArgs{
+					ApiId:    httpApi.ID(),
+					RouteKey: pulumi.String(route),
+					Target:   pulumi.Sprintf("integrations/%s", apiIntegration.ID()),
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			_, err = apigatewayv2.NewStage(ctx, "tag-compliance-api-stage", &apigatewayv2.StageArgs{
+				ApiId:      httpApi.ID(),
+				Name:       pulumi.String("$default"),
+				AutoDeploy: pulumi.Bool(true),
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = lambda.NewPermission(ctx, "apigateway-invoke-lambda", &lambda.PermissionArgs{
+				Action:    pulumi.String("lambda:InvokeFunction"),
+				Function:  lambdaFunc.Name,
+				Principal: pulumi.String("apigateway.amazonaws.com"),
+				SourceArn: pulumi.Sprintf("%s/*/*", httpApi.ExecutionArn),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
 		// Create EventBridge rule for resource creation events
 		eventPattern, _ := json.Marshal(map[string]interface{}{
 			"source":      []string{"aws.ec2", "aws.s3", "aws.rds", "aws.lambda", "aws.elasticloadbalancing", "aws.autoscaling"},
@@ -235,12 +456,15 @@ func main() {
 			},
 		})
 
-		eventRule, err := cloudwatch.NewEventRule(ctx, "tag-compliance-rule", &cloudwatch.EventRuleArgs{
-			Name:         pulumi.String("tag-compliance-resource-creation"),
-			Description:  pulumi.String("Capture AWS resource creation events for tag compliance checking"),
-			EventPattern: pulumi.String(string(eventPattern)),
+		// Scheduled full-inventory sweep: drives the checker to page tag:GetResources across
+		// supported services and re-check every resource, catching drift that the CloudTrail-driven
+		// rule above never sees (tags removed after creation, resources created before deployment).
+		scanScheduleRule, err := cloudwatch.NewEventRule(ctx, "tag-compliance-scan-schedule", &cloudwatch.EventRuleArgs{
+			Name:               pulumi.String("tag-compliance-scan-schedule"),
+			Description:        pulumi.String("Periodically trigger a full inventory scan to catch drift missed by the event-driven path"),
+			ScheduleExpression: pulumi.String(scanSchedule),
 			Tags: pulumi.StringMap{
-				"Project": pulumi.String("TagCompliance"),
+				"Project":   pulumi.String("TagCompliance"),
 				"ManagedBy": pulumi.String("Pulumi"),
 			},
 		})
@@ -248,37 +472,283 @@ func main() {
 			return err
 		}
 
-		// Grant EventBridge permission to invoke Lambda
-		_, err = lambda.NewPermission(ctx, "eventbridge-invoke-lambda", &lambda.PermissionArgs{
-			Action:       pulumi.String("lambda:InvokeFunction"),
-			Function:     lambdaFunc.Name,
-			Principal:    pulumi.String("events.amazonaws.com"),
-			SourceArn:    eventRule.Arn,
+		_, err = lambda.NewPermission(ctx, "eventbridge-invoke-lambda-scan", &lambda.PermissionArgs{
+			Action:    pulumi.String("lambda:InvokeFunction"),
+			Function:  lambdaFunc.Name,
+			Principal: pulumi.String("events.amazonaws.com"),
+			SourceArn: scanScheduleRule.Arn,
 		})
 		if err != nil {
 			return err
 		}
 
-		// Create EventBridge target
-		_, err = cloudwatch.NewEventTarget(ctx, "tag-compliance-target", &cloudwatch.EventTargetArgs{
-			Rule:     eventRule.Name,
+		_, err = cloudwatch.NewEventTarget(ctx, "tag-compliance-scan-target", &cloudwatch.EventTargetArgs{
+			Rule:     scanScheduleRule.Name,
 			TargetId: pulumi.String("tag-compliance-lambda"),
 			Arn:      lambdaFunc.Arn,
+			Input:    pulumi.String(`{"scanType":"full"}`),
+			DeadLetterConfig: &cloudwatch.EventTargetDeadLetterConfigArgs{
+				Arn: scanDlq.Arn,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = cloudwatch.NewMetricAlarm(ctx, "tag-compliance-scan-dlq-alarm", &cloudwatch.MetricAlarmArgs{
+			Name:               pulumi.String("tag-compliance-scan-dlq-depth"),
+			ComparisonOperator: pulumi.String("GreaterThanThreshold"),
+			EvaluationPeriods:  pulumi.Int(1),
+			MetricName:         pulumi.String("ApproximateNumberOfMessagesVisible"),
+			Namespace:          pulumi.String("AWS/SQS"),
+			Period:             pulumi.Int(300),
+			Statistic:          pulumi.String("Maximum"),
+			Threshold:          pulumi.Float64(0),
+			Dimensions: pulumi.StringMap{
+				"QueueName": scanDlq.Name,
+			},
+			AlarmDescription: pulumi.String("Alerts when undelivered or crashed scan invocations accumulate on the scan DLQ"),
+			Tags: pulumi.StringMap{
+				"Project":   pulumi.String("TagCompliance"),
+				"ManagedBy": pulumi.String("Pulumi"),
+			},
 		})
 		if err != nil {
 			return err
 		}
 
+		// Dashboard and alarm on the KPI custom metrics the Lambda emits under the TagCompliance
+		// namespace, with a per-service breakdown of non-compliant resources.
+		kpiDashboardBody := pulumi.All(lambdaFunc.Name).ApplyT(func(args []interface{}) (string, error) {
+			funcName := args[0].(string)
+			body, _ := json.Marshal(map[string]interface{}{
+				"widgets": []map[string]interface{}{
+					{
+						"type": "metric",
+						"properties": map[string]interface{}{
+							"title": "Compliance rate",
+							"metrics": []interface{}{
+								[]interface{}{"TagCompliance", "RulesEvaluated", "FunctionName", funcName},
+								[]interface{}{"TagCompliance", "NonCompliantResources", "FunctionName", funcName},
+							},
+							"stat":   "Sum",
+							"period": 300,
+						},
+					},
+					{
+						"type": "metric",
+						"properties": map[string]interface{}{
+							"title": "Non-compliant resources by service",
+							"metrics": []interface{}{
+								[]interface{}{"TagCompliance", "NonCompliantResources", "Service", "ec2"},
+								[]interface{}{"TagCompliance", "NonCompliantResources", "Service", "s3"},
+								[]interface{}{"TagCompliance", "NonCompliantResources", "Service", "rds"},
+								[]interface{}{"TagCompliance", "NonCompliantResources", "Service", "lambda"},
+								[]interface{}{"TagCompliance", "NonCompliantResources", "Service", "elb"},
+								[]interface{}{"TagCompliance", "NonCompliantResources", "Service", "asg"},
+							},
+							"stat":   "Sum",
+							"period": 300,
+						},
+					},
+					{
+						"type": "metric",
+						"properties": map[string]interface{}{
+							"title":   "Bedrock invocation errors",
+							"metrics": []interface{}{[]interface{}{"TagCompliance", "BedrockInvocationErrors"}},
+							"stat":    "Sum",
+							"period":  300,
+						},
+					},
+					{
+						"type": "metric",
+						"properties": map[string]interface{}{
+							"title":   "Lark notification failures",
+							"metrics": []interface{}{[]interface{}{"TagCompliance", "LarkNotificationFailures"}},
+							"stat":    "Sum",
+							"period":  300,
+						},
+					},
+				},
+			})
+			return string(body), nil
+		}).(pulumi.StringOutput)
+
+		_, err = cloudwatch.NewDashboard(ctx, "tag-compliance-kpi-dashboard", &cloudwatch.DashboardArgs{
+			DashboardName: pulumi.String("TagComplianceKPIs"),
+			DashboardBody: kpiDashboardBody,
+		})
+		if err != nil {
+			return err
+		}
+
+		nonComplianceAlarm, err := cloudwatch.NewMetricAlarm(ctx, "tag-compliance-non-compliance-alarm", &cloudwatch.MetricAlarmArgs{
+			Name:               pulumi.String("tag-compliance-non-compliance-threshold"),
+			ComparisonOperator: pulumi.String("GreaterThanThreshold"),
+			EvaluationPeriods:  pulumi.Int(3),
+			MetricName:         pulumi.String("NonCompliantResources"),
+			Namespace:          pulumi.String("TagCompliance"),
+			Period:             pulumi.Int(300),
+			Statistic:          pulumi.String("Sum"),
+			Threshold:          pulumi.Float64(nonComplianceThreshold),
+			AlarmDescription:   pulumi.String("Fires when non-compliant resources exceed the configured threshold over 15 minutes"),
+			AlarmActions: pulumi.StringArray{
+				alarmTopic.Arn,
+			},
+			Tags: pulumi.StringMap{
+				"Project":   pulumi.String("TagCompliance"),
+				"ManagedBy": pulumi.String("Pulumi"),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		// Fan the rule + target out to every configured region. In "replicated" mode each
+		// non-primary region also gets its own Lambda (EventBridge targets must live in the same
+		// region as the rule), since a direct cross-region Lambda target isn't supported. "central"
+		// mode skips that per-region Lambda and targets the primary region's Lambda ARN directly,
+		// which the validation above guarantees only happens when regions == [region].
+		eventRuleArns := pulumi.StringMap{}
+		eventRuleNames := pulumi.StringMap{}
+		regionalLambdaArns := pulumi.StringMap{}
+
+		for _, r := range regions {
+			var opts []pulumi.ResourceOption
+			if r != region {
+				regionalProvider, err := aws.NewProvider(ctx, "provider-"+r, &aws.ProviderArgs{
+					Region: pulumi.String(r),
+				})
+				if err != nil {
+					return err
+				}
+				opts = append(opts, pulumi.Provider(regionalProvider))
+			}
+
+			targetFunc := lambdaFunc
+			if r != region && deploymentMode == "replicated" {
+				regionalRole, err := iam.NewRole(ctx, "tag-compliance-lambda-role-"+r, &iam.RoleArgs{
+					Name:             pulumi.String(fmt.Sprintf("tag-compliance-lambda-role-%s", r)),
+					AssumeRolePolicy: pulumi.String(string(assumeRolePolicy)),
+					Tags: pulumi.StringMap{
+						"Project":   pulumi.String("TagCompliance"),
+						"ManagedBy": pulumi.String("Pulumi"),
+					},
+				}, opts...)
+				if err != nil {
+					return err
+				}
+
+				_, err = iam.NewRolePolicyAttachment(ctx, "lambda-basic-execution-"+r, &iam.RolePolicyAttachmentArgs{
+					Role:      regionalRole.Name,
+					PolicyArn: pulumi.String("arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"),
+				}, opts...)
+				if err != nil {
+					return err
+				}
+
+				_, err = iam.NewRolePolicy(ctx, "tag-compliance-lambda-policy-"+r, &iam.RolePolicyArgs{
+					Role:   regionalRole.Name,
+					Policy: customPolicy,
+				}, opts...)
+				if err != nil {
+					return err
+				}
+
+				regionalFunc, err := lambda.NewFunction(ctx, "tag-compliance-checker-"+r, &lambda.FunctionArgs{
+					Name:       pulumi.String("tag-compliance-checker"),
+					Runtime:    pulumi.String("python3.12"),
+					Handler:    pulumi.String("handler.lambda_handler"),
+					Role:       regionalRole.Arn,
+					MemorySize: pulumi.Int(lambdaMemory),
+					Timeout:    pulumi.Int(lambdaTimeout),
+					Architectures: pulumi.StringArray{
+						pulumi.String(lambdaArchitecture),
+					},
+					Code: pulumi.NewFileArchive("../lambda/function.zip"),
+					Environment: &lambda.FunctionEnvironmentArgs{
+						Variables: pulumi.StringMap{
+							"BEDROCK_MODEL_ID": pulumi.String(bedrockModelId),
+							"RULES_TABLE_NAME": rulesTable.Name,
+							"LARK_SECRET_NAME": pulumi.String(larkSecretName),
+							"LOG_LEVEL":        pulumi.String("INFO"),
+							"PYTHONPATH":       pulumi.String("/var/task"),
+						},
+					},
+					Tags: pulumi.StringMap{
+						"Project":   pulumi.String("TagCompliance"),
+						"ManagedBy": pulumi.String("Pulumi"),
+					},
+				}, opts...)
+				if err != nil {
+					return err
+				}
+				targetFunc = regionalFunc
+				regionalLambdaArns[r] = regionalFunc.Arn
+			}
+
+			eventRule, err := cloudwatch.NewEventRule(ctx, "tag-compliance-rule-"+r, &cloudwatch.EventRuleArgs{
+				Name:         pulumi.String("tag-compliance-resource-creation"),
+				Description:  pulumi.String("Capture AWS resource creation events for tag compliance checking"),
+				EventPattern: pulumi.String(string(eventPattern)),
+				Tags: pulumi.StringMap{
+					"Project":   pulumi.String("TagCompliance"),
+					"ManagedBy": pulumi.String("Pulumi"),
+				},
+			}, opts...)
+			if err != nil {
+				return err
+			}
+
+			// Grant EventBridge permission to invoke Lambda
+			_, err = lambda.NewPermission(ctx, "eventbridge-invoke-lambda-"+r, &lambda.PermissionArgs{
+				Action:    pulumi.String("lambda:InvokeFunction"),
+				Function:  targetFunc.Name,
+				Principal: pulumi.String("events.amazonaws.com"),
+				SourceArn: eventRule.Arn,
+			}, opts...)
+			if err != nil {
+				return err
+			}
+
+			// Create EventBridge target
+			_, err = cloudwatch.NewEventTarget(ctx, "tag-compliance-target-"+r, &cloudwatch.EventTargetArgs{
+				Rule:     eventRule.Name,
+				TargetId: pulumi.String("tag-compliance-lambda"),
+				Arn:      targetFunc.Arn,
+			}, opts...)
+			if err != nil {
+				return err
+			}
+
+			eventRuleArns[r] = eventRule.Arn
+			eventRuleNames[r] = eventRule.Name
+		}
+
 		// Export outputs
 		ctx.Export("region", pulumi.String(region))
+		ctx.Export("regions", pulumi.ToStringArray(regions))
+		ctx.Export("deploymentMode", pulumi.String(deploymentMode))
 		ctx.Export("lambdaFunctionName", lambdaFunc.Name)
 		ctx.Export("lambdaFunctionArn", lambdaFunc.Arn)
+		ctx.Export("regionalLambdaFunctionArns", regionalLambdaArns)
 		ctx.Export("dynamoDBTableName", rulesTable.Name)
 		ctx.Export("dynamoDBTableArn", rulesTable.Arn)
-		ctx.Export("eventRuleName", eventRule.Name)
-		ctx.Export("eventRuleArn", eventRule.Arn)
+		ctx.Export("eventRuleNames", eventRuleNames)
+		ctx.Export("eventRuleArns", eventRuleArns)
 		ctx.Export("logGroupName", logGroup.Name)
 		ctx.Export("bedrockModelId", pulumi.String(bedrockModelId))
+		ctx.Export("scanDlqArn", scanDlq.Arn)
+		ctx.Export("scanDlqUrl", scanDlq.Url)
+		ctx.Export("scanScheduleRuleArn", scanScheduleRule.Arn)
+		ctx.Export("centralLambdaRoleArn", lambdaRole.Arn)
+		ctx.Export("expectedSpokeRoleName", pulumi.String(spokeTrustRoleName))
+		ctx.Export("kpiDashboardName", pulumi.String("TagComplianceKPIs"))
+		ctx.Export("nonComplianceAlarmArn", nonComplianceAlarm.Arn)
+		ctx.Export("alarmTopicArn", alarmTopic.Arn)
+		if enableApi {
+			ctx.Export("apiInvokeUrl", httpApi.ApiEndpoint)
+		}
 
 		return nil
 	})
@@ -7,6 +7,7 @@ import (
 	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws"
 	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/cloudwatch"
 	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/iam"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/sqs"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
 	"github.com/pulumi/pulumi/sdk/v3/go/pulumi/config"
 )
@@ -35,6 +36,15 @@ func main() {
 		hubRegion := cfg.Require("hubRegion")
 		hubEventBusArn := cfg.Require("hubEventBusArn")
 
+		eventRetries := cfg.GetInt("eventRetries")
+		if eventRetries == 0 {
+			eventRetries = 3
+		}
+		eventMaxAgeSeconds := cfg.GetInt("eventMaxAgeSeconds")
+		if eventMaxAgeSeconds == 0 {
+			eventMaxAgeSeconds = 3600
+		}
+
 		// Validate configuration
 		if hubAccountId == "" || hubRegion == "" || hubEventBusArn == "" {
 			return fmt.Errorf("spoke deployment requires hubAccountId, hubRegion, and hubEventBusArn configuration")
@@ -124,12 +134,67 @@ func main() {
 			return err
 		}
 
+		// Dead-letter queue for forwarded events, so a transient events:PutEvents failure against
+		// the hub's event bus doesn't silently drop a compliance event
+		forwardDlq, err := sqs.NewQueue(ctx, "tag-compliance-forward-dlq", &sqs.QueueArgs{
+			Name:                    pulumi.String("tag-compliance-forward-dlq"),
+			MessageRetentionSeconds: pulumi.Int(1209600),
+			Tags: pulumi.StringMap{
+				"Project":   pulumi.String("TagCompliance"),
+				"ManagedBy": pulumi.String("Pulumi"),
+				"Component": pulumi.String("spoke"),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		forwardDlqPolicy := pulumi.All(forwardDlq.Arn, eventRule.Arn).ApplyT(func(args []any) (string, error) {
+			queueArn := args[0].(string)
+			ruleArn := args[1].(string)
+			policy, _ := json.Marshal(map[string]any{
+				"Version": "2012-10-17",
+				"Statement": []map[string]any{
+					{
+						"Sid":    "AllowEventBridgeSendMessage",
+						"Effect": "Allow",
+						"Principal": map[string]any{
+							"Service": "events.amazonaws.com",
+						},
+						"Action":   "sqs:SendMessage",
+						"Resource": queueArn,
+						"Condition": map[string]any{
+							"ArnEquals": map[string]any{
+								"aws:SourceArn": ruleArn,
+							},
+						},
+					},
+				},
+			})
+			return string(policy), nil
+		}).(pulumi.StringOutput)
+
+		_, err = sqs.NewQueuePolicy(ctx, "tag-compliance-forward-dlq-policy", &sqs.QueuePolicyArgs{
+			QueueUrl: forwardDlq.Url,
+			Policy:   forwardDlqPolicy,
+		})
+		if err != nil {
+			return err
+		}
+
 		// Create target to forward events to hub account's event bus
 		_, err = cloudwatch.NewEventTarget(ctx, "tag-compliance-hub-target", &cloudwatch.EventTargetArgs{
 			Rule:     eventRule.Name,
 			TargetId: pulumi.String("hub-event-bus"),
 			Arn:      pulumi.String(hubEventBusArn),
 			RoleArn:  crossAccountRole.Arn,
+			DeadLetterConfig: &cloudwatch.EventTargetDeadLetterConfigArgs{
+				Arn: forwardDlq.Arn,
+			},
+			RetryPolicy: &cloudwatch.EventTargetRetryPolicyArgs{
+				MaximumRetryAttempts:     pulumi.Int(eventRetries),
+				MaximumEventAgeInSeconds: pulumi.Int(eventMaxAgeSeconds),
+			},
 		})
 		if err != nil {
 			return err
@@ -144,6 +209,8 @@ func main() {
 		ctx.Export("hubAccountId", pulumi.String(hubAccountId))
 		ctx.Export("hubRegion", pulumi.String(hubRegion))
 		ctx.Export("hubEventBusArn", pulumi.String(hubEventBusArn))
+		ctx.Export("forwardDlqArn", forwardDlq.Arn)
+		ctx.Export("forwardDlqUrl", forwardDlq.Url)
 
 		return nil
 	})
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/iam"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// spokeTrustRoleName is the role name a spoke account must provision (via NewSpokeTrustRole) for
+// the central Lambda to assume into. The central role's policy builds its AssumeRole ARNs from each
+// tagCompliance:targetAccounts entry's roleName, so that roleName must be this same name.
+const spokeTrustRoleName = "tag-compliance-spoke-trust"
+
+// SpokeTrustRoleArgs configures the IAM role a spoke account provisions to let the central
+// tag-compliance Lambda assume into it for cross-account tag checking.
+type SpokeTrustRoleArgs struct {
+	// CentralLambdaRoleArn is the ARN of the central account's tag-compliance-lambda-role.
+	CentralLambdaRoleArn pulumi.StringInput
+}
+
+// SpokeTrustRole is a ComponentResource a spoke account's own stack instantiates to grant the
+// central tag-compliance Lambda read-only tag access into the account.
+type SpokeTrustRole struct {
+	pulumi.ResourceState
+
+	RoleArn pulumi.StringOutput `pulumi:"roleArn"`
+}
+
+// NewSpokeTrustRole provisions the spoke-account IAM role trusted by the central Lambda role,
+// granting it the same ResourceTagging/tag:GetResources permissions as the local Lambda.
+func NewSpokeTrustRole(ctx *pulumi.Context, name string, args *SpokeTrustRoleArgs, opts ...pulumi.ResourceOption) (*SpokeTrustRole, error) {
+	component := &SpokeTrustRole{}
+	if err := ctx.RegisterComponentResource("tagCompliance:index:SpokeTrustRole", name, component, opts...); err != nil {
+		return nil, err
+	}
+
+	assumeRolePolicy := args.CentralLambdaRoleArn.ToStringOutput().ApplyT(func(roleArn string) (string, error) {
+		policy, _ := json.Marshal(map[string]interface{}{
+			"Version": "2012-10-17",
+			"Statement": []map[string]interface{}{
+				{
+					"Effect":    "Allow",
+					"Principal": map[string]interface{}{"AWS": roleArn},
+					"Action":    "sts:AssumeRole",
+				},
+			},
+		})
+		return string(policy), nil
+	}).(pulumi.StringOutput)
+
+	role, err := iam.NewRole(ctx, name+"-role", &iam.RoleArgs{
+		Name:             pulumi.String(spokeTrustRoleName),
+		AssumeRolePolicy: assumeRolePolicy,
+		Tags: pulumi.StringMap{
+			"Project":   pulumi.String("TagCompliance"),
+			"ManagedBy": pulumi.String("Pulumi"),
+		},
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, err
+	}
+
+	policy, _ := json.Marshal(map[string]interface{}{
+		"Version": "2012-10-17",
+		"Statement": []map[string]interface{}{
+			{
+				"Sid":    "ResourceTagging",
+				"Effect": "Allow",
+				"Action": []string{
+					"ec2:DescribeTags",
+					"ec2:DescribeInstances",
+					"ec2:DescribeVolumes",
+					"s3:GetBucketTagging",
+					"s3:ListBucket",
+					"rds:DescribeDBInstances",
+					"rds:ListTagsForResource",
+					"lambda:GetFunction",
+					"lambda:ListTags",
+					"elasticloadbalancing:DescribeTags",
+					"autoscaling:DescribeTags",
+					"tag:GetResources",
+					"tag:GetTagKeys",
+					"tag:GetTagValues",
+				},
+				"Resource": "*",
+			},
+		},
+	})
+
+	_, err = iam.NewRolePolicy(ctx, name+"-policy", &iam.RolePolicyArgs{
+		Role:   role.Name,
+		Policy: pulumi.String(string(policy)),
+	}, pulumi.Parent(component))
+	if err != nil {
+		return nil, err
+	}
+
+	component.RoleArn = role.Arn
+	if err := ctx.RegisterResourceOutputs(component, pulumi.Map{
+		"roleArn": component.RoleArn,
+	}); err != nil {
+		return nil, err
+	}
+
+	return component, nil
+}